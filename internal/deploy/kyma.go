@@ -2,15 +2,16 @@ package deploy
 
 import (
 	"bytes"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
+	"strings"
 	"text/template"
 
 	"github.com/kyma-project/cli/internal/kube"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
 )
 
 const kymaCRTemplate = `apiVersion: v1
@@ -44,8 +45,9 @@ const (
 	certManagerURL = "https://github.com/cert-manager/cert-manager/releases/download/%s/cert-manager.yaml"
 )
 
-// Kyma deploys the Kyma CR. If no kymaCRPath is provided, it deploys the default CR.
-func Kyma(k8s kube.KymaKube, namespace, channel, kymaCRpath, certManagerVersion string, dryRun bool) error {
+// Kyma deploys the Kyma CR, followed by the manifests produced by sources,
+// in order. If no kymaCRPath is provided, it deploys the default CR.
+func Kyma(k8s kube.KymaKube, namespace, channel, kymaCRpath string, sources []ManifestSource, dryRun bool) error {
 	// TODO delete deploy.go when the old reconciler is gone.
 	yamlBytes := bytes.Buffer{}
 
@@ -84,19 +86,17 @@ func Kyma(k8s kube.KymaKube, namespace, channel, kymaCRpath, certManagerVersion
 
 	result := yamlBytes.Bytes()
 
-	if certManagerVersion != "" {
-		// Get the data
-		resp, err := http.Get(fmt.Sprintf(certManagerURL, certManagerVersion))
+	ctx := context.Background()
+	for i, source := range sources {
+		manifest, err := source.Fetch(ctx)
 		if err != nil {
-			return fmt.Errorf("could not download cert-manager: %w", err)
+			return fmt.Errorf("could not fetch manifest source %d: %w", i, err)
 		}
-
-		certManagerBytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return fmt.Errorf("could not write cert-manager data to yaml: %w", err)
+		if err := validateYAML(manifest); err != nil {
+			return fmt.Errorf("manifest source %d produced invalid YAML: %w", i, err)
 		}
 		result = append(result, []byte("\n---\n")...)
-		result = append(result, certManagerBytes...)
+		result = append(result, manifest...)
 	}
 
 	if dryRun {
@@ -106,3 +106,32 @@ func Kyma(k8s kube.KymaKube, namespace, channel, kymaCRpath, certManagerVersion
 
 	return k8s.Apply(result)
 }
+
+// KymaWithCertManager is a convenience constructor preserving the original
+// Kyma behaviour of installing a given cert-manager release straight off
+// its GitHub release URL, for callers that have not opted into the
+// ManifestSource pipeline (e.g. local file, OCI, or Helm sources with
+// digest/signature verification).
+func KymaWithCertManager(k8s kube.KymaKube, namespace, channel, kymaCRpath, certManagerVersion string, dryRun bool) error {
+	var sources []ManifestSource
+	if certManagerVersion != "" {
+		sources = append(sources, HTTPManifestSource{URL: fmt.Sprintf(certManagerURL, certManagerVersion)})
+	}
+	return Kyma(k8s, namespace, channel, kymaCRpath, sources, dryRun)
+}
+
+// validateYAML checks that every "---"-separated document in manifest
+// parses as YAML, so a broken ManifestSource fails before anything is
+// applied to the cluster rather than mid-way through.
+func validateYAML(manifest []byte) error {
+	for _, doc := range strings.Split(string(manifest), "\n---\n") {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		var obj map[string]interface{}
+		if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+			return fmt.Errorf("could not parse document: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kyma-project/cli/internal/kube"
+)
+
+func TestValidateYAML(t *testing.T) {
+	if err := validateYAML([]byte("apiVersion: v1\nkind: Namespace\n---\napiVersion: v1\nkind: ConfigMap\n")); err != nil {
+		t.Fatalf("validateYAML() returned error for valid documents: %v", err)
+	}
+
+	if err := validateYAML([]byte("not: [valid")); err == nil {
+		t.Fatalf("validateYAML() returned no error for an invalid document")
+	}
+}
+
+// fakeKymaKube implements kube.KymaKube by embedding it and only overriding
+// Apply, so the test doesn't need to stub out the rest of the interface.
+type fakeKymaKube struct {
+	kube.KymaKube
+	applied  []byte
+	applyErr error
+}
+
+func (f *fakeKymaKube) Apply(manifest []byte) error {
+	f.applied = manifest
+	return f.applyErr
+}
+
+type fakeManifestSource struct {
+	manifest []byte
+	err      error
+}
+
+func (s fakeManifestSource) Fetch(_ context.Context) ([]byte, error) {
+	return s.manifest, s.err
+}
+
+func TestKymaAppliesSourcesInOrder(t *testing.T) {
+	k8s := &fakeKymaKube{}
+	sources := []ManifestSource{
+		fakeManifestSource{manifest: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: first\n")},
+		fakeManifestSource{manifest: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: second\n")},
+	}
+
+	if err := Kyma(k8s, "kyma-system", "regular", "", sources, false); err != nil {
+		t.Fatalf("Kyma() returned error: %v", err)
+	}
+
+	firstIdx := strings.Index(string(k8s.applied), "name: first")
+	secondIdx := strings.Index(string(k8s.applied), "name: second")
+	if firstIdx == -1 || secondIdx == -1 || firstIdx > secondIdx {
+		t.Fatalf("Kyma() did not apply sources in order, got:\n%s", k8s.applied)
+	}
+}
+
+func TestKymaFailsClosedWhenASourceErrors(t *testing.T) {
+	k8s := &fakeKymaKube{}
+	sources := []ManifestSource{
+		fakeManifestSource{err: fmt.Errorf("boom")},
+	}
+
+	if err := Kyma(k8s, "kyma-system", "regular", "", sources, false); err == nil {
+		t.Fatalf("Kyma() returned no error when a source failed to fetch")
+	}
+	if k8s.applied != nil {
+		t.Fatalf("Kyma() called Apply despite a failed source")
+	}
+}
+
+func TestKymaFailsClosedOnInvalidYAMLFromASource(t *testing.T) {
+	k8s := &fakeKymaKube{}
+	sources := []ManifestSource{
+		fakeManifestSource{manifest: []byte("not: [valid")},
+	}
+
+	if err := Kyma(k8s, "kyma-system", "regular", "", sources, false); err == nil {
+		t.Fatalf("Kyma() returned no error for a source producing invalid YAML")
+	}
+	if k8s.applied != nil {
+		t.Fatalf("Kyma() called Apply despite invalid YAML from a source")
+	}
+}
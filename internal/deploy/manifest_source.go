@@ -0,0 +1,190 @@
+package deploy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/kyma-project/cli/pkg/module"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+)
+
+// ManifestSource produces a chunk of Kubernetes manifest YAML to be applied
+// alongside the Kyma CR. Kyma streams every source through a YAML
+// splitter/validator before handing the result off to k8s.Apply, so sources
+// can be mixed freely: a local file, a digest-pinned HTTP download, an OCI
+// artifact pulled through the same OCM stack module.Remote uses, or a
+// rendered Helm chart.
+type ManifestSource interface {
+	Fetch(ctx context.Context) ([]byte, error)
+}
+
+// FileManifestSource reads a manifest from a local file path.
+type FileManifestSource struct {
+	Path string
+}
+
+func (s FileManifestSource) Fetch(_ context.Context) ([]byte, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read manifest file %q: %w", s.Path, err)
+	}
+	return data, nil
+}
+
+// HTTPManifestSource downloads a manifest over HTTP(S). If Digest is set
+// (as "sha256:<hex>"), the downloaded bytes are verified against it and
+// Fetch fails closed on a mismatch, so e.g. a cert-manager release can be
+// pinned to a known-good digest instead of trusting the URL unconditionally.
+type HTTPManifestSource struct {
+	URL    string
+	Digest string
+}
+
+func (s HTTPManifestSource) Fetch(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not build request for %q: %w", s.URL, err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not download manifest from %q: %w", s.URL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not download manifest from %q: unexpected status %s", s.URL, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read manifest downloaded from %q: %w", s.URL, err)
+	}
+
+	if s.Digest != "" {
+		if err := verifyDigest(data, s.Digest); err != nil {
+			return nil, fmt.Errorf("manifest downloaded from %q failed verification: %w", s.URL, err)
+		}
+	}
+
+	return data, nil
+}
+
+// OCIManifestSource pulls a single resource out of a pushed component
+// version using the same module.Remote a `kyma alpha create module` push
+// went through, so a manifest can be distributed and pulled the same way
+// modules themselves are. If Verify is set, the component version's
+// signature is checked before the resource is read, and Fetch fails
+// closed when verification fails.
+type OCIManifestSource struct {
+	Remote   module.Remote
+	Name     string
+	Version  string
+	Resource string
+	Digest   string
+	Verify   *module.VerifyOptions
+}
+
+func (s OCIManifestSource) Fetch(_ context.Context) ([]byte, error) {
+	octx := ocm.DefaultContext()
+
+	cv, err := s.Remote.Pull(octx, s.Name, s.Version)
+	if err != nil {
+		return nil, fmt.Errorf("could not pull %s:%s from %q: %w", s.Name, s.Version, s.Remote.Registry, err)
+	}
+	defer cv.Close()
+
+	if s.Verify != nil {
+		if err := module.Verify(cv, *s.Verify); err != nil {
+			return nil, err
+		}
+	}
+
+	var data []byte
+	found := false
+	for _, res := range cv.GetResources() {
+		if res.Meta().GetName() != s.Resource {
+			continue
+		}
+		method, err := res.AccessMethod()
+		if err != nil {
+			return nil, fmt.Errorf("could not access resource %q of %s:%s: %w", s.Resource, s.Name, s.Version, err)
+		}
+		data, err = method.Get()
+		_ = method.Close()
+		if err != nil {
+			return nil, fmt.Errorf("could not read resource %q of %s:%s: %w", s.Resource, s.Name, s.Version, err)
+		}
+		found = true
+		break
+	}
+	if !found {
+		return nil, fmt.Errorf("resource %q not found in %s:%s", s.Resource, s.Name, s.Version)
+	}
+
+	if s.Digest != "" {
+		if err := verifyDigest(data, s.Digest); err != nil {
+			return nil, fmt.Errorf("resource %q of %s:%s failed verification: %w", s.Resource, s.Name, s.Version, err)
+		}
+	}
+
+	return data, nil
+}
+
+// HelmChartManifestSource renders a local or already-pulled Helm chart into
+// plain YAML, so chart-packaged prerequisites (e.g. a CNI or gateway) can be
+// installed through the same k8s.Apply path as everything else.
+type HelmChartManifestSource struct {
+	ChartPath   string
+	ReleaseName string
+	Namespace   string
+	Values      map[string]interface{}
+}
+
+func (s HelmChartManifestSource) Fetch(_ context.Context) ([]byte, error) {
+	chrt, err := loader.Load(s.ChartPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not load chart at %q: %w", s.ChartPath, err)
+	}
+
+	values, err := chartutil.ToRenderValues(chrt, s.Values, chartutil.ReleaseOptions{
+		Name:      s.ReleaseName,
+		Namespace: s.Namespace,
+		IsInstall: true,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not build render values for chart %q: %w", s.ChartPath, err)
+	}
+
+	rendered, err := engine.Render(chrt, values)
+	if err != nil {
+		return nil, fmt.Errorf("could not render chart %q: %w", s.ChartPath, err)
+	}
+
+	var out []byte
+	for _, content := range rendered {
+		if len(out) > 0 {
+			out = append(out, []byte("\n---\n")...)
+		}
+		out = append(out, []byte(content)...)
+	}
+
+	return out, nil
+}
+
+func verifyDigest(data []byte, expected string) error {
+	sum := sha256.Sum256(data)
+	actual := "sha256:" + hex.EncodeToString(sum[:])
+	if actual != expected {
+		return fmt.Errorf("digest mismatch: expected %s, got %s", expected, actual)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package deploy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileManifestSourceFetch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.yaml")
+	const body = "apiVersion: v1\nkind: Namespace\n"
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatalf("could not write test manifest file: %v", err)
+	}
+
+	source := FileManifestSource{Path: path}
+	data, err := source.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch() returned error: %v", err)
+	}
+	if string(data) != body {
+		t.Fatalf("Fetch() = %q, want %q", data, body)
+	}
+}
+
+func TestFileManifestSourceFetchMissingFile(t *testing.T) {
+	source := FileManifestSource{Path: filepath.Join(t.TempDir(), "does-not-exist.yaml")}
+	if _, err := source.Fetch(context.Background()); err == nil {
+		t.Fatalf("Fetch() returned no error for a missing file")
+	}
+}
+
+func TestVerifyDigest(t *testing.T) {
+	data := []byte("manifest contents")
+	sum := sha256.Sum256(data)
+	digest := "sha256:" + hex.EncodeToString(sum[:])
+
+	if err := verifyDigest(data, digest); err != nil {
+		t.Fatalf("verifyDigest() returned error for a matching digest: %v", err)
+	}
+
+	if err := verifyDigest(data, "sha256:0000000000000000000000000000000000000000000000000000000000000000"); err == nil {
+		t.Fatalf("verifyDigest() returned no error for a mismatching digest")
+	}
+}
+
+func TestHTTPManifestSourceFetch(t *testing.T) {
+	const body = "apiVersion: v1\nkind: Namespace\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	source := HTTPManifestSource{URL: server.URL}
+	data, err := source.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch() returned error: %v", err)
+	}
+	if string(data) != body {
+		t.Fatalf("Fetch() = %q, want %q", data, body)
+	}
+}
+
+func TestHTTPManifestSourceFetchVerifiesDigest(t *testing.T) {
+	const body = "apiVersion: v1\nkind: Namespace\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	sum := sha256.Sum256([]byte(body))
+	matching := "sha256:" + hex.EncodeToString(sum[:])
+
+	source := HTTPManifestSource{URL: server.URL, Digest: matching}
+	if _, err := source.Fetch(context.Background()); err != nil {
+		t.Fatalf("Fetch() returned error for a matching digest: %v", err)
+	}
+
+	source.Digest = "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+	if _, err := source.Fetch(context.Background()); err == nil {
+		t.Fatalf("Fetch() returned no error for a mismatching digest")
+	}
+}
+
+func TestHTTPManifestSourceFetchFailsOnNon200(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	source := HTTPManifestSource{URL: server.URL}
+	if _, err := source.Fetch(context.Background()); err == nil {
+		t.Fatalf("Fetch() returned no error for a 404 response")
+	}
+}
@@ -0,0 +1,211 @@
+package bundle
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/open-component-model/ocm/pkg/common"
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/comparch"
+	componentTransfer "github.com/open-component-model/ocm/pkg/contexts/ocm/transfer"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler/standard"
+)
+
+// bundleableResourceTypes are the resource kinds Bundle pulls into the store
+// so a target environment never needs network access to install them.
+var bundleableResourceTypes = map[string]bool{
+	"ociImage":    true,
+	"ociArtifact": true,
+	"helmChart":   true,
+}
+
+// Manifest records everything Unbundle needs to replay a store back into a
+// target Remote: the digest of the root component descriptor, the digests
+// of every bundled resource blob, and the full Manifest of every referenced
+// component version that was bundled alongside it (recursively, so a
+// reference's own references are preserved too). It is produced by Bundle
+// and must be persisted next to the store (e.g. as module.tar's
+// manifest.json) by the caller.
+type Manifest struct {
+	Name             string     `json:"name"`
+	Version          string     `json:"version"`
+	DescriptorDigest string     `json:"descriptorDigest"`
+	ResourceDigests  []string   `json:"resourceDigests,omitempty"`
+	References       []Manifest `json:"references,omitempty"`
+}
+
+// ComponentVersionResolver resolves a referenced component version by name
+// and version. Bundle needs one to walk compdesc.References because a
+// comparch.ComponentArchive's own ComponentVersionAccess.Repository() is
+// scoped to just the single descriptor the archive was opened with, not to
+// whatever remote registry the referenced component versions actually live
+// in - the same repository Remote.GetRepository resolves against satisfies
+// this interface and should be passed in by the caller.
+type ComponentVersionResolver interface {
+	LookupComponentVersion(name, version string) (ocm.ComponentVersionAccess, error)
+}
+
+// Bundle packages archive - its component descriptor, every referenced
+// component version (compdesc.References, bundled recursively and resolved
+// via resolver) and every image-typed or Helm-chart resource reachable from
+// it - into store. The returned Manifest is the entry point Unbundle needs
+// to replay the store into a target repository.
+func Bundle(archive *comparch.ComponentArchive, store Store, resolver ComponentVersionResolver) (Manifest, error) {
+	cv := archive.ComponentVersionAccess
+
+	descriptorBytes, err := compdesc.Encode(cv.GetDescriptor())
+	if err != nil {
+		return Manifest{}, fmt.Errorf("could not encode component descriptor for %s:%s: %w", cv.GetName(), cv.GetVersion(), err)
+	}
+	descDigest, err := store.Put(bytes.NewReader(descriptorBytes))
+	if err != nil {
+		return Manifest{}, fmt.Errorf("could not store component descriptor for %s:%s: %w", cv.GetName(), cv.GetVersion(), err)
+	}
+
+	manifest := Manifest{
+		Name:             cv.GetName(),
+		Version:          cv.GetVersion(),
+		DescriptorDigest: descDigest,
+	}
+
+	for _, res := range cv.GetResources() {
+		if !bundleableResourceTypes[res.Meta().GetType()] {
+			continue
+		}
+		digest, err := bundleResource(res, store)
+		if err != nil {
+			return Manifest{}, fmt.Errorf("could not bundle resource %q: %w", res.Meta().GetName(), err)
+		}
+		manifest.ResourceDigests = append(manifest.ResourceDigests, digest)
+	}
+
+	for _, ref := range cv.GetDescriptor().References {
+		refCV, err := resolver.LookupComponentVersion(ref.ComponentName, ref.Version)
+		if err != nil {
+			return Manifest{}, fmt.Errorf("could not resolve referenced component version %s:%s: %w", ref.ComponentName, ref.Version, err)
+		}
+		refManifest, err := Bundle(&comparch.ComponentArchive{ComponentVersionAccess: refCV}, store, resolver)
+		if err != nil {
+			return Manifest{}, fmt.Errorf("could not bundle referenced component %s:%s: %w", ref.ComponentName, ref.Version, err)
+		}
+		manifest.References = append(manifest.References, refManifest)
+	}
+
+	return manifest, nil
+}
+
+// bundleResource copies the blob backing res into store and returns its
+// digest, so Unbundle can find it again without the network.
+func bundleResource(res ocm.ResourceAccess, store Store) (string, error) {
+	method, err := res.AccessMethod()
+	if err != nil {
+		return "", fmt.Errorf("could not determine access method: %w", err)
+	}
+	defer method.Close()
+
+	reader, err := method.Reader()
+	if err != nil {
+		return "", fmt.Errorf("could not open resource blob: %w", err)
+	}
+	defer reader.Close()
+
+	digest, err := store.Put(reader)
+	if err != nil {
+		return "", fmt.Errorf("could not store resource blob: %w", err)
+	}
+
+	return digest, nil
+}
+
+// Unbundle replays a store produced by Bundle - located via manifest - into
+// repo, re-materializing it as a plain component-transport-archive
+// directory first so the existing comparch/transfer machinery (the same
+// machinery Remote.Push uses) can take over. Every manifest.References entry
+// is replayed first (recursively), so a referenced component version is
+// already present in repo by the time the root component version is
+// transferred and can resolve it.
+func Unbundle(store Store, manifest Manifest, repo cpi.Repository, ctx ocm.Context, overwrite bool) (ocm.ComponentVersionAccess, error) {
+	for _, ref := range manifest.References {
+		if _, err := Unbundle(store, ref, repo, ctx, overwrite); err != nil {
+			return nil, fmt.Errorf("could not unbundle referenced component %s:%s: %w", ref.Name, ref.Version, err)
+		}
+	}
+
+	tmpDir, err := os.MkdirTemp("", "kyma-unbundle-*")
+	if err != nil {
+		return nil, fmt.Errorf("could not create staging directory for unbundle: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := materializeArchive(store, manifest, tmpDir); err != nil {
+		return nil, fmt.Errorf("could not materialize bundle for %s:%s: %w", manifest.Name, manifest.Version, err)
+	}
+
+	archive, err := comparch.Open(ctx, accessobj.ACC_READONLY, tmpDir, accessio.PathFileSystem(nil), accessio.FormatDirectory)
+	if err != nil {
+		return nil, fmt.Errorf("could not open materialized bundle for %s:%s: %w", manifest.Name, manifest.Version, err)
+	}
+	defer archive.Close()
+
+	transferHandler, err := standard.New(standard.Overwrite(overwrite))
+	if err != nil {
+		return nil, fmt.Errorf("could not setup bundle transfer: %w", err)
+	}
+
+	if err := componentTransfer.TransferVersion(
+		common.NewLoggingPrinter(ctx.Logger()), nil, archive.ComponentVersionAccess, repo, transferHandler,
+	); err != nil {
+		return nil, fmt.Errorf("could not replay bundle for %s:%s into target repository: %w", manifest.Name, manifest.Version, err)
+	}
+
+	return repo.LookupComponentVersion(manifest.Name, manifest.Version)
+}
+
+// materializeArchive writes the component descriptor and every bundled
+// resource blob from store into path as a component-transport-archive
+// directory, so it can be opened with comparch.Open.
+func materializeArchive(store Store, manifest Manifest, path string) error {
+	descriptor, err := readBlob(store, manifest.DescriptorDigest)
+	if err != nil {
+		return fmt.Errorf("could not read bundled component descriptor: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(path, comparch.ComponentDescriptorFileName), descriptor, os.ModePerm); err != nil {
+		return fmt.Errorf("could not write component descriptor: %w", err)
+	}
+
+	blobsDir := filepath.Join(path, "blobs")
+	if err := os.MkdirAll(blobsDir, os.ModePerm); err != nil {
+		return fmt.Errorf("could not create blobs directory: %w", err)
+	}
+	for _, digest := range manifest.ResourceDigests {
+		data, err := readBlob(store, digest)
+		if err != nil {
+			return fmt.Errorf("could not read bundled resource blob %q: %w", digest, err)
+		}
+		_, hex, err := splitDigest(digest)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(blobsDir, hex), data, os.ModePerm); err != nil {
+			return fmt.Errorf("could not write bundled resource blob %q: %w", digest, err)
+		}
+	}
+
+	return nil
+}
+
+func readBlob(store Store, digest string) ([]byte, error) {
+	r, err := store.Get(digest)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
@@ -0,0 +1,106 @@
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/open-component-model/ocm/pkg/common/accessio"
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/compdesc"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/comparch"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/ctf"
+)
+
+var testChartResourceMeta = comparch.NewResourceMeta("chart", "helmChart", comparch.LocalRelation)
+
+// stubResolver resolves the exact component versions it was built with,
+// standing in for the remote repository a real Bundle call resolves
+// compdesc.References against.
+type stubResolver struct {
+	versions map[string]ocm.ComponentVersionAccess
+}
+
+func (r stubResolver) LookupComponentVersion(name, version string) (ocm.ComponentVersionAccess, error) {
+	cv, ok := r.versions[name+":"+version]
+	if !ok {
+		return nil, fmt.Errorf("stubResolver: no such component version %s:%s", name, version)
+	}
+	return cv, nil
+}
+
+func TestBundleUnbundleRoundTripWithReference(t *testing.T) {
+	ctx := ocm.New()
+
+	depArchive, err := comparch.New(ctx, accessobj.ACC_CREATE, t.TempDir(), nil, comparch.NewComponentDescriptor("test.kyma-project.io/dep", "v0.0.1"))
+	if err != nil {
+		t.Fatalf("could not create dependency archive: %v", err)
+	}
+
+	chartDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(chartDir, "Chart.yaml"), []byte("name: dep\nversion: v0.0.1\n"), 0o600); err != nil {
+		t.Fatalf("could not write fake chart content: %v", err)
+	}
+	if err := depArchive.SetResourceBlob(testChartResourceMeta, comparch.DirectoryBlobAccess(chartDir), "", nil); err != nil {
+		t.Fatalf("could not add chart resource to dependency archive: %v", err)
+	}
+
+	rootArchive, err := comparch.New(ctx, accessobj.ACC_CREATE, t.TempDir(), nil, comparch.NewComponentDescriptor("test.kyma-project.io/root", "v0.0.1"))
+	if err != nil {
+		t.Fatalf("could not create root archive: %v", err)
+	}
+	rootArchive.ComponentVersionAccess.GetDescriptor().References = append(
+		rootArchive.ComponentVersionAccess.GetDescriptor().References,
+		compdesc.ComponentReference{
+			ElementMeta:   compdesc.ElementMeta{Name: "dep", Version: "v0.0.1"},
+			ComponentName: "test.kyma-project.io/dep",
+		},
+	)
+
+	resolver := stubResolver{versions: map[string]ocm.ComponentVersionAccess{
+		"test.kyma-project.io/dep:v0.0.1": depArchive.ComponentVersionAccess,
+	}}
+
+	store := NewMemStore()
+	manifest, err := Bundle(rootArchive, store, resolver)
+	if err != nil {
+		t.Fatalf("Bundle() returned error: %v", err)
+	}
+
+	if len(manifest.References) != 1 {
+		t.Fatalf("Bundle() manifest has %d references, want 1", len(manifest.References))
+	}
+	if manifest.References[0].Name != "test.kyma-project.io/dep" || manifest.References[0].Version != "v0.0.1" {
+		t.Fatalf("Bundle() reference manifest = %+v, want test.kyma-project.io/dep:v0.0.1", manifest.References[0])
+	}
+	if len(manifest.References[0].ResourceDigests) != 1 {
+		t.Fatalf("Bundle() bundled %d resources for the dependency, want 1 (the chart)", len(manifest.References[0].ResourceDigests))
+	}
+
+	targetRepo, err := ctf.Create(ctx, accessobj.ACC_CREATE, t.TempDir(), 0o755, accessio.FormatDirectory)
+	if err != nil {
+		t.Fatalf("could not create target repository: %v", err)
+	}
+	defer targetRepo.Close()
+
+	if _, err := Unbundle(store, manifest, targetRepo, ctx, false); err != nil {
+		t.Fatalf("Unbundle() returned error: %v", err)
+	}
+
+	depCV, err := targetRepo.LookupComponentVersion("test.kyma-project.io/dep", "v0.0.1")
+	if err != nil {
+		t.Fatalf("referenced component version was not replayed into the target repository: %v", err)
+	}
+	defer depCV.Close()
+	if len(depCV.GetResources()) != 1 {
+		t.Fatalf("replayed dependency has %d resources, want 1 (the bundled chart)", len(depCV.GetResources()))
+	}
+
+	rootCV, err := targetRepo.LookupComponentVersion("test.kyma-project.io/root", "v0.0.1")
+	if err != nil {
+		t.Fatalf("root component version was not replayed into the target repository: %v", err)
+	}
+	defer rootCV.Close()
+}
@@ -0,0 +1,171 @@
+package bundle
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const (
+	blobsDir     = "blobs"
+	indexJSON    = "index.json"
+	ociLayoutDir = "oci-layout"
+
+	// genericBlobMediaType is used for every index.json entry, since a
+	// bundle stores component descriptors and resource blobs side by side
+	// rather than a single well-known OCI manifest type.
+	genericBlobMediaType = "application/vnd.kyma-project.cli.bundle.blob"
+)
+
+// ociIndexDescriptor is the subset of the OCI image-spec descriptor fields
+// standard tooling (e.g. `oras`, `skopeo`) needs to list and fetch a blob
+// out of index.json.
+type ociIndexDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+// ociIndex is the minimal OCI image-spec index.json shape.
+type ociIndex struct {
+	SchemaVersion int                  `json:"schemaVersion"`
+	Manifests     []ociIndexDescriptor `json:"manifests"`
+}
+
+// FileStore is a Store backed by an OCI-layout compatible directory, so a
+// bundle produced by Bundle can also be inspected with standard OCI tooling
+// (e.g. `oras`, `skopeo`). The layout on disk is:
+//
+//	<root>/oci-layout
+//	<root>/index.json
+//	<root>/blobs/sha256/<hex>
+type FileStore struct {
+	root string
+}
+
+// NewFileStore opens (and, if necessary, initializes) an OCI-layout
+// directory at root as a FileStore.
+func NewFileStore(root string) (*FileStore, error) {
+	if err := os.MkdirAll(filepath.Join(root, blobsDir, "sha256"), os.ModePerm); err != nil {
+		return nil, fmt.Errorf("could not create bundle store at %q: %w", root, err)
+	}
+
+	layoutMarker := filepath.Join(root, ociLayoutDir)
+	if _, err := os.Stat(layoutMarker); os.IsNotExist(err) {
+		if err := os.WriteFile(layoutMarker, []byte(`{"imageLayoutVersion":"1.0.0"}`), os.ModePerm); err != nil {
+			return nil, fmt.Errorf("could not write oci-layout marker in %q: %w", root, err)
+		}
+	}
+
+	index := filepath.Join(root, indexJSON)
+	if _, err := os.Stat(index); os.IsNotExist(err) {
+		if err := writeIndex(index, ociIndex{SchemaVersion: 2}); err != nil {
+			return nil, fmt.Errorf("could not write index.json in %q: %w", root, err)
+		}
+	}
+
+	return &FileStore{root: root}, nil
+}
+
+func (s *FileStore) blobPath(digest string) (string, error) {
+	algorithm, hex, err := splitDigest(digest)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.root, blobsDir, algorithm, hex), nil
+}
+
+func (s *FileStore) Put(r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("could not read blob content: %w", err)
+	}
+
+	digest := Digest(data)
+	path, err := s.blobPath(digest)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return "", fmt.Errorf("could not create blob directory for %q: %w", digest, err)
+	}
+	if _, err := os.Stat(path); err == nil {
+		return digest, nil
+	}
+	if err := os.WriteFile(path, data, os.ModePerm); err != nil {
+		return "", fmt.Errorf("could not write blob %q: %w", digest, err)
+	}
+
+	if err := s.addToIndex(ociIndexDescriptor{
+		MediaType: genericBlobMediaType,
+		Digest:    digest,
+		Size:      int64(len(data)),
+	}); err != nil {
+		return "", fmt.Errorf("could not update index.json for blob %q: %w", digest, err)
+	}
+
+	return digest, nil
+}
+
+// addToIndex appends desc to index.json, so every blob Put stores is
+// reflected there for standard OCI tooling to inspect.
+func (s *FileStore) addToIndex(desc ociIndexDescriptor) error {
+	path := filepath.Join(s.root, indexJSON)
+
+	index, err := readIndex(path)
+	if err != nil {
+		return err
+	}
+	index.Manifests = append(index.Manifests, desc)
+
+	return writeIndex(path, index)
+}
+
+func readIndex(path string) (ociIndex, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ociIndex{}, fmt.Errorf("could not read %q: %w", path, err)
+	}
+	var index ociIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return ociIndex{}, fmt.Errorf("could not parse %q: %w", path, err)
+	}
+	return index, nil
+}
+
+func writeIndex(path string, index ociIndex) error {
+	data, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("could not marshal index.json: %w", err)
+	}
+	return os.WriteFile(path, data, os.ModePerm)
+}
+
+func (s *FileStore) Get(digest string) (io.ReadCloser, error) {
+	path, err := s.blobPath(digest)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open blob %q: %w", digest, err)
+	}
+	return f, nil
+}
+
+func (s *FileStore) Has(digest string) (bool, error) {
+	path, err := s.blobPath(digest)
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("could not stat blob %q: %w", digest, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,55 @@
+package bundle
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// MemStore is an in-memory Store. It backs the same Bundle/Unbundle code
+// paths as FileStore and exists so bundling logic can be tested without
+// touching disk.
+type MemStore struct {
+	mu    sync.RWMutex
+	blobs map[string][]byte
+}
+
+// NewMemStore creates an empty in-memory Store.
+func NewMemStore() *MemStore {
+	return &MemStore{blobs: map[string][]byte{}}
+}
+
+func (s *MemStore) Put(r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("could not read blob content: %w", err)
+	}
+
+	digest := Digest(data)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blobs[digest] = data
+
+	return digest, nil
+}
+
+func (s *MemStore) Get(digest string) (io.ReadCloser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, ok := s.blobs[digest]
+	if !ok {
+		return nil, fmt.Errorf("blob %q not found in store", digest)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *MemStore) Has(digest string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.blobs[digest]
+	return ok, nil
+}
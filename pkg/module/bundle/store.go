@@ -0,0 +1,46 @@
+// Package bundle implements an air-gapped, on-disk store for component
+// versions pushed via module.Remote, together with every OCI image and Helm
+// chart they reference. A store can be produced against a connected registry
+// via Bundle and later replayed into any target Remote via Unbundle, without
+// the target ever needing network access to the original sources.
+package bundle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+)
+
+// Store is a content-addressable blob store that can back a bundle. Blobs
+// are always addressed by their "sha256:<hex>" digest, matching the OCI
+// content-addressable-storage model so the same code can read and write an
+// OCI-layout directory on disk or an in-memory store used by tests.
+type Store interface {
+	// Put writes the content read from r and returns its digest.
+	Put(r io.Reader) (digest string, err error)
+	// Get opens the blob addressed by digest for reading. The caller must
+	// close the returned reader.
+	Get(digest string) (io.ReadCloser, error)
+	// Has reports whether a blob with the given digest is already present.
+	Has(digest string) (bool, error)
+}
+
+// Digest returns the "sha256:<hex>" digest of the given bytes, in the same
+// format used to address blobs in a Store and in an OCI-layout blobs/sha256
+// directory.
+func Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// splitDigest splits a "sha256:<hex>" digest into its algorithm and hex
+// parts, as used for the blobs/<algorithm>/<hex> layout on disk.
+func splitDigest(digest string) (algorithm, hex string, err error) {
+	for i := 0; i < len(digest); i++ {
+		if digest[i] == ':' {
+			return digest[:i], digest[i+1:], nil
+		}
+	}
+	return "", "", fmt.Errorf("invalid digest %q: missing algorithm separator", digest)
+}
@@ -0,0 +1,125 @@
+package bundle
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDigestIsStable(t *testing.T) {
+	data := []byte("component descriptor bytes")
+	if Digest(data) != Digest(data) {
+		t.Fatalf("Digest is not deterministic for the same input")
+	}
+	if Digest(data) == Digest([]byte("something else")) {
+		t.Fatalf("Digest collided for different inputs")
+	}
+}
+
+func TestMemStoreRoundTrip(t *testing.T) {
+	store := NewMemStore()
+	assertStoreRoundTrip(t, store)
+}
+
+func TestFileStoreRoundTrip(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStore() returned error: %v", err)
+	}
+	assertStoreRoundTrip(t, store)
+
+	// the OCI-layout markers required for standard tooling must be present.
+	root := store.root
+	if _, err := os.Stat(filepath.Join(root, ociLayoutDir)); err != nil {
+		t.Fatalf("expected oci-layout marker to exist: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, indexJSON)); err != nil {
+		t.Fatalf("expected index.json to exist: %v", err)
+	}
+}
+
+func TestFileStoreIndexReflectsPutBlobs(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileStore() returned error: %v", err)
+	}
+
+	first, err := store.Put(bytes.NewReader([]byte("first blob")))
+	if err != nil {
+		t.Fatalf("Put() returned error: %v", err)
+	}
+	second, err := store.Put(bytes.NewReader([]byte("second blob")))
+	if err != nil {
+		t.Fatalf("Put() returned error: %v", err)
+	}
+	// putting the same content twice must not duplicate the index entry.
+	if _, err := store.Put(bytes.NewReader([]byte("first blob"))); err != nil {
+		t.Fatalf("Put() returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(store.root, indexJSON))
+	if err != nil {
+		t.Fatalf("could not read index.json: %v", err)
+	}
+	var index ociIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		t.Fatalf("could not parse index.json: %v", err)
+	}
+
+	digests := map[string]bool{}
+	for _, desc := range index.Manifests {
+		digests[desc.Digest] = true
+	}
+	if len(index.Manifests) != 2 {
+		t.Fatalf("index.json has %d manifests, want 2: %v", len(index.Manifests), index.Manifests)
+	}
+	if !digests[first] || !digests[second] {
+		t.Fatalf("index.json manifests %v missing %q or %q", index.Manifests, first, second)
+	}
+}
+
+func assertStoreRoundTrip(t *testing.T, store Store) {
+	t.Helper()
+
+	content := []byte("hello bundle")
+	digest, err := store.Put(bytes.NewReader(content))
+	if err != nil {
+		t.Fatalf("Put() returned error: %v", err)
+	}
+	if digest != Digest(content) {
+		t.Fatalf("Put() returned digest %q, want %q", digest, Digest(content))
+	}
+
+	has, err := store.Has(digest)
+	if err != nil {
+		t.Fatalf("Has() returned error: %v", err)
+	}
+	if !has {
+		t.Fatalf("Has() reported missing blob right after Put()")
+	}
+
+	r, err := store.Get(digest)
+	if err != nil {
+		t.Fatalf("Get() returned error: %v", err)
+	}
+	defer r.Close()
+
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("could not read blob: %v", err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Fatalf("Get() returned %q, want %q", got, content)
+	}
+
+	missing, err := store.Has(Digest([]byte("never put")))
+	if err != nil {
+		t.Fatalf("Has() returned error for missing digest: %v", err)
+	}
+	if missing {
+		t.Fatalf("Has() reported a blob that was never stored")
+	}
+}
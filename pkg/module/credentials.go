@@ -0,0 +1,183 @@
+package module
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/open-component-model/ocm/pkg/contexts/credentials"
+	"github.com/open-component-model/ocm/pkg/contexts/credentials/repositories/dockerconfig"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+)
+
+// CredentialProvider resolves credentials.Credentials for a registry host.
+// Lookup returns nil, nil if the provider has no credentials for registry,
+// so providers can be chained with ChainCredentialProvider and the first
+// match wins.
+type CredentialProvider interface {
+	Lookup(ctx cpi.Context, registry string) (credentials.Credentials, error)
+}
+
+// ChainCredentialProvider tries every provider in order and returns the
+// first non-nil result, so e.g. a staging ghcr and a customer's Harbor can
+// each be configured once and resolved automatically per push. A provider
+// that errors (e.g. a FileCredentialProvider pointing at a file that
+// doesn't exist) is treated the same as one returning no match: Lookup
+// moves on to the next provider instead of aborting the whole chain, the
+// same convention DockerConfigCredentialProvider itself follows.
+type ChainCredentialProvider []CredentialProvider
+
+func (c ChainCredentialProvider) Lookup(ctx cpi.Context, registry string) (credentials.Credentials, error) {
+	for _, provider := range c {
+		creds, err := provider.Lookup(ctx, registry)
+		if err != nil {
+			continue
+		}
+		if creds != nil {
+			return creds, nil
+		}
+	}
+	return nil, nil
+}
+
+// DockerConfigCredentialProvider looks up credentials for registry in the
+// user's ~/.docker/config.json, the same store `docker login` writes to.
+type DockerConfigCredentialProvider struct{}
+
+func (DockerConfigCredentialProvider) Lookup(ctx cpi.Context, registry string) (credentials.Credentials, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, nil
+	}
+
+	path := filepath.Join(home, ".docker", "config.json")
+	repo, err := dockerconfig.NewRepository(ctx.CredentialsContext(), path, true)
+	if err != nil {
+		return nil, nil
+	}
+
+	creds, err := repo.LookupCredentials(registryHost(registry))
+	if err != nil {
+		return nil, nil
+	}
+	return creds, nil
+}
+
+// DirectCredentialProvider returns the given username/password (or token,
+// used as the password if none is set), regardless of registry. If neither a
+// username nor a password/token is set, it returns nil. It backs the
+// existing `--credentials`/`--token` CLI flags.
+type DirectCredentialProvider struct {
+	Username string
+	Password string
+	Token    string
+}
+
+func (d DirectCredentialProvider) Lookup(_ cpi.Context, _ string) (credentials.Credentials, error) {
+	password := d.Password
+	if password == "" {
+		password = d.Token
+	}
+	if d.Username == "" && password == "" {
+		return nil, nil
+	}
+	return credentials.DirectCredentials{
+		"username": d.Username,
+		"password": password,
+	}, nil
+}
+
+// registryCredentialsFile is the YAML format loaded by
+// FileCredentialProvider: a list of named credentials, one per registry.
+type registryCredentialsFile struct {
+	Registries []registryCredentialEntry `json:"registries"`
+}
+
+type registryCredentialEntry struct {
+	Name     string `json:"name"`
+	Registry string `json:"registry"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Token    string `json:"token,omitempty"`
+	// Insecure marks a registry as not requiring credentials at all (the
+	// same semantics as Remote.Insecure), so e.g. a local plain-HTTP mirror
+	// listed alongside authenticated registries doesn't need a dummy
+	// username/password to match.
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+// FileCredentialProvider loads a YAML file listing multiple named registry
+// credentials and resolves them by matching the registry hostname, so a
+// single workflow can push to several registries (e.g. a staging ghcr and a
+// customer's Harbor) without re-authenticating between invocations.
+type FileCredentialProvider struct {
+	Path string
+}
+
+func (f FileCredentialProvider) Lookup(_ cpi.Context, registry string) (credentials.Credentials, error) {
+	data, err := os.ReadFile(f.Path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read credentials file %q: %w", f.Path, err)
+	}
+
+	var file registryCredentialsFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("could not parse credentials file %q: %w", f.Path, err)
+	}
+
+	host := registryHost(registry)
+	for _, entry := range file.Registries {
+		if registryHost(entry.Registry) != host {
+			continue
+		}
+		if entry.Insecure {
+			return credentials.NewCredentials(nil), nil
+		}
+		password := entry.Password
+		if password == "" {
+			password = entry.Token
+		}
+		return credentials.DirectCredentials{
+			"username": entry.Username,
+			"password": password,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// CredentialProviderFlags binds the --credentials-file flag that selects
+// additional CredentialProvider instances for Remote.CredentialProviders, so
+// a future `kyma alpha create module push` command can register
+// o.AddFlags(cmd.Flags()) and pass o.Providers() into Remote.
+//
+// NOTE: no cmd/ package in this tree owns a module push/verify command yet,
+// so AddFlags is not actually called from anywhere - wiring that in is
+// still outstanding and belongs with whichever request adds that command.
+type CredentialProviderFlags struct {
+	CredentialsFile string
+}
+
+// AddFlags registers --credentials-file on flags.
+func (o *CredentialProviderFlags) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&o.CredentialsFile, "credentials-file", "", "path to a YAML file listing named registry credentials (see FileCredentialProvider)")
+}
+
+// Providers builds the CredentialProvider chain requested by the flags, for
+// use as Remote.CredentialProviders.
+func (o *CredentialProviderFlags) Providers() []CredentialProvider {
+	if o.CredentialsFile == "" {
+		return nil
+	}
+	return []CredentialProvider{FileCredentialProvider{Path: o.CredentialsFile}}
+}
+
+// registryHost extracts the hostname a registry is reachable under, e.g.
+// "ghcr.io/org/sub" => "ghcr.io", the same way the docker config lookup
+// resolves the host to query.
+func registryHost(registry string) string {
+	return strings.Split(NoSchemeURL(registry), "/")[0]
+}
@@ -0,0 +1,161 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func writeCredentialsFile(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "credentials.yaml")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("could not write test credentials file: %v", err)
+	}
+	return path
+}
+
+func TestFileCredentialProviderRoundTrip(t *testing.T) {
+	path := writeCredentialsFile(t, `
+registries:
+  - name: staging-ghcr
+    registry: ghcr.io
+    username: staging-user
+    password: staging-pass
+  - name: customer-harbor
+    registry: harbor.customer.example.com
+    token: harbor-token
+`)
+
+	provider := FileCredentialProvider{Path: path}
+
+	creds, err := provider.Lookup(nil, "ghcr.io/org/sub")
+	if err != nil {
+		t.Fatalf("Lookup() returned error: %v", err)
+	}
+	if creds == nil {
+		t.Fatalf("Lookup() returned no credentials for ghcr.io/org/sub")
+	}
+	if creds["username"] != "staging-user" || creds["password"] != "staging-pass" {
+		t.Fatalf("Lookup() returned %v, want staging-user/staging-pass", creds)
+	}
+
+	creds, err = provider.Lookup(nil, "https://harbor.customer.example.com/project/repo")
+	if err != nil {
+		t.Fatalf("Lookup() returned error: %v", err)
+	}
+	if creds == nil || creds["password"] != "harbor-token" {
+		t.Fatalf("Lookup() did not fall back to token for harbor entry, got %v", creds)
+	}
+
+	creds, err = provider.Lookup(nil, "docker.io/library/nginx")
+	if err != nil {
+		t.Fatalf("Lookup() returned error: %v", err)
+	}
+	if creds != nil {
+		t.Fatalf("Lookup() returned credentials for an unconfigured registry: %v", creds)
+	}
+}
+
+func TestFileCredentialProviderInsecureEntryNeedsNoCredentials(t *testing.T) {
+	path := writeCredentialsFile(t, `
+registries:
+  - name: local-mirror
+    registry: localhost:5000
+    insecure: true
+`)
+
+	provider := FileCredentialProvider{Path: path}
+
+	creds, err := provider.Lookup(nil, "localhost:5000/library/nginx")
+	if err != nil {
+		t.Fatalf("Lookup() returned error: %v", err)
+	}
+	if creds == nil {
+		t.Fatalf("Lookup() returned nil for an insecure entry, want non-nil empty credentials")
+	}
+	if creds["username"] != "" || creds["password"] != "" {
+		t.Fatalf("Lookup() returned %v for an insecure entry, want no username/password", creds)
+	}
+}
+
+func TestFileCredentialProviderInsecureEntryDoesNotLeakToOtherRegistries(t *testing.T) {
+	path := writeCredentialsFile(t, `
+registries:
+  - name: local-mirror
+    registry: localhost:5000
+    insecure: true
+  - name: customer-harbor
+    registry: harbor.customer.example.com
+    username: customer-user
+    password: customer-pass
+`)
+
+	provider := FileCredentialProvider{Path: path}
+
+	creds, err := provider.Lookup(nil, "harbor.customer.example.com/project/repo")
+	if err != nil {
+		t.Fatalf("Lookup() returned error: %v", err)
+	}
+	if creds == nil || creds["username"] != "customer-user" || creds["password"] != "customer-pass" {
+		t.Fatalf("Lookup() = %v, want customer-user/customer-pass for harbor.customer.example.com despite an unrelated insecure entry", creds)
+	}
+}
+
+func TestRegistryHost(t *testing.T) {
+	cases := map[string]string{
+		"ghcr.io":                     "ghcr.io",
+		"ghcr.io/org/sub":             "ghcr.io",
+		"https://ghcr.io/org/sub":     "ghcr.io",
+		"harbor.customer.example.com": "harbor.customer.example.com",
+	}
+	for in, want := range cases {
+		if got := registryHost(in); got != want {
+			t.Errorf("registryHost(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCredentialProviderFlagsAddFlagsRegistersFlag(t *testing.T) {
+	var flags CredentialProviderFlags
+	set := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.AddFlags(set)
+
+	if err := set.Parse([]string{"--credentials-file", "/tmp/creds.yaml"}); err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+	if flags.CredentialsFile != "/tmp/creds.yaml" {
+		t.Fatalf("--credentials-file = %q, want /tmp/creds.yaml", flags.CredentialsFile)
+	}
+
+	providers := flags.Providers()
+	if len(providers) != 1 {
+		t.Fatalf("Providers() returned %d providers, want 1", len(providers))
+	}
+	if fileProvider, ok := providers[0].(FileCredentialProvider); !ok || fileProvider.Path != "/tmp/creds.yaml" {
+		t.Fatalf("Providers()[0] = %+v, want a FileCredentialProvider pointing at /tmp/creds.yaml", providers[0])
+	}
+}
+
+func TestCredentialProviderFlagsProvidersEmptyWithoutFile(t *testing.T) {
+	var flags CredentialProviderFlags
+	if providers := flags.Providers(); providers != nil {
+		t.Fatalf("Providers() = %v, want nil when --credentials-file is unset", providers)
+	}
+}
+
+func TestChainCredentialProviderFirstMatchWins(t *testing.T) {
+	empty := DirectCredentialProvider{}
+	direct := DirectCredentialProvider{Username: "u", Password: "p"}
+	chain := ChainCredentialProvider{empty, direct}
+
+	creds, err := chain.Lookup(nil, "ghcr.io")
+	if err != nil {
+		t.Fatalf("Lookup() returned error: %v", err)
+	}
+	if creds == nil || creds["username"] != "u" {
+		t.Fatalf("Lookup() = %v, want credentials from the second provider", creds)
+	}
+}
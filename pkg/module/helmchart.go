@@ -0,0 +1,36 @@
+package module
+
+import (
+	"fmt"
+
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/comparch"
+)
+
+// PushChart packages the Helm chart directory at chartPath as a single
+// "helmChart" resource of a component named name:version and pushes it to
+// this Remote, so a `kyma alpha create module` build can produce a
+// consumable chart on disk and an OCI-hosted copy in one step.
+func (r *Remote) PushChart(chartPath, name, version string) error {
+	ctx := ocm.DefaultContext()
+
+	archive, err := comparch.New(ctx, accessobj.ACC_CREATE, "", nil, comparch.NewComponentDescriptor(name, version))
+	if err != nil {
+		return fmt.Errorf("could not create archive for chart %s:%s: %w", name, version, err)
+	}
+
+	if err := archive.SetResourceBlob(
+		chartResourceMeta, comparch.DirectoryBlobAccess(chartPath), "", nil,
+	); err != nil {
+		return fmt.Errorf("could not add chart directory %q as resource: %w", chartPath, err)
+	}
+
+	if _, err := r.Push(archive, true); err != nil {
+		return fmt.Errorf("could not push chart %s:%s: %w", name, version, err)
+	}
+
+	return nil
+}
+
+var chartResourceMeta = comparch.NewResourceMeta("chart", "helmChart", comparch.LocalRelation)
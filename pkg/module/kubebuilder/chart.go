@@ -0,0 +1,319 @@
+package kubebuilder
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// manifestGroups maps the Kind of a rendered manifest to the template file
+// it belongs to in an idiomatic Helm chart layout.
+var manifestGroups = map[string]string{
+	"Deployment":         "deployments.yaml",
+	"StatefulSet":        "deployments.yaml",
+	"Service":            "services.yaml",
+	"ServiceAccount":     "rbac.yaml",
+	"Role":               "rbac.yaml",
+	"RoleBinding":        "rbac.yaml",
+	"ClusterRole":        "rbac.yaml",
+	"ClusterRoleBinding": "rbac.yaml",
+	"ConfigMap":          "configmaps.yaml",
+	"Secret":             "secrets.yaml",
+}
+
+const miscTemplateFile = "misc.yaml"
+
+// manifestDoc is a single "---"-separated YAML document, kept alongside its
+// parsed Kind/Name so it can be grouped, value-extracted and templatized.
+type manifestDoc struct {
+	kind string
+	name string
+	raw  []byte
+}
+
+// splitManifests parses the raw kustomize.Build output into individual
+// documents, skipping blank documents and documents without a kind. CRDs
+// are returned like any other document; writeHelmChart separates them (see
+// crdFileIdentifier).
+func splitManifests(yml []byte) ([]manifestDoc, error) {
+	var docs []manifestDoc
+	for _, chunk := range strings.Split(string(yml), "\n---\n") {
+		if strings.TrimSpace(chunk) == "" {
+			continue
+		}
+		var meta struct {
+			Kind     string `json:"kind"`
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+		}
+		if err := yaml.Unmarshal([]byte(chunk), &meta); err != nil {
+			return nil, fmt.Errorf("could not parse manifest document: %w", err)
+		}
+		if meta.Kind == "" {
+			continue
+		}
+		docs = append(docs, manifestDoc{kind: meta.Kind, name: meta.Metadata.Name, raw: []byte(chunk)})
+	}
+	return docs, nil
+}
+
+// chartValues is the generated values.yaml content: image/tag/replicas for
+// the first Deployment/StatefulSet found (exposed at the top level for
+// convenience), every Deployment/StatefulSet's own image/tag/replicas keyed
+// by its name (so a second workload never gets cross-wired to the first
+// one's values), plus one Config entry per ConfigMap/Secret literal.
+type chartValues struct {
+	Image        chartImageValues               `json:"image"`
+	ReplicaCount *int32                         `json:"replicaCount,omitempty"`
+	Config       map[string]map[string]string   `json:"config,omitempty"`
+	Workloads    map[string]chartWorkloadValues `json:"workloads,omitempty"`
+
+	// primaryWorkload is the name of the first Deployment/StatefulSet
+	// found, i.e. the one whose values are duplicated at the top level
+	// above; not marshaled into values.yaml.
+	primaryWorkload string
+}
+
+type chartWorkloadValues struct {
+	Image        chartImageValues `json:"image,omitempty"`
+	ReplicaCount *int32           `json:"replicaCount,omitempty"`
+}
+
+type chartImageValues struct {
+	Repository string `json:"repository,omitempty"`
+	Tag        string `json:"tag,omitempty"`
+}
+
+var imageRegexp = regexp.MustCompile(`^(.*):([^:/]+)$`)
+
+// writeHelmChart replaces a single rendered.yaml with an idiomatic Helm
+// chart: per-kind template files, a generated values.yaml for the
+// image/tag/replicas/config literals found in the rendered manifests, the
+// templates rewritten to reference those values, and a standard
+// _helpers.tpl. CRDs are left untouched - Helm does not template those.
+func writeHelmChart(yml []byte, chartName, outPath, crdsPath string) error {
+	docs, err := splitManifests(yml)
+	if err != nil {
+		return err
+	}
+
+	grouped := map[string][]manifestDoc{}
+	var crds []manifestDoc
+	for _, doc := range docs {
+		if strings.Contains(strings.ToLower(doc.kind), crdFileIdentifier) {
+			crds = append(crds, doc)
+			continue
+		}
+		file := manifestGroups[doc.kind]
+		if file == "" {
+			file = miscTemplateFile
+		}
+		grouped[file] = append(grouped[file], doc)
+	}
+
+	values := extractValues(docs)
+
+	for file, groupDocs := range grouped {
+		content, err := templatizeGroup(groupDocs, values)
+		if err != nil {
+			return fmt.Errorf("could not templatize %s: %w", file, err)
+		}
+		if err := os.WriteFile(filepath.Join(outPath, file), content, os.ModePerm); err != nil {
+			return fmt.Errorf("could not write template %s: %w", file, err)
+		}
+	}
+
+	for _, crd := range crds {
+		fileName := strings.ToLower(crd.name) + ".yaml"
+		if err := os.WriteFile(filepath.Join(crdsPath, fileName), crd.raw, os.ModePerm); err != nil {
+			return fmt.Errorf("could not write CRD %s: %w", fileName, err)
+		}
+	}
+
+	valuesYAML, err := yaml.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("could not marshal values.yaml: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(filepath.Dir(outPath), "values.yaml"), valuesYAML, os.ModePerm); err != nil {
+		return fmt.Errorf("could not write values.yaml: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(outPath, "_helpers.tpl"), []byte(helpersTplFor(chartName)), os.ModePerm); err != nil {
+		return fmt.Errorf("could not write _helpers.tpl: %w", err)
+	}
+
+	return nil
+}
+
+// extractValues pulls the image/tag and replica count off every
+// Deployment/StatefulSet found, keyed by its own name so templatizeGroup can
+// rewrite each workload against its own values instead of whichever
+// workload happened to be found first, plus every ConfigMap/Secret literal
+// keyed by the ConfigMap/Secret's own name, so e.g. a kustomize
+// ConfigMapGenerator literal becomes an overridable `config.<name>.<key>`
+// value instead of a baked-in constant. The first Deployment/StatefulSet's
+// values are additionally duplicated at the top level, so the common
+// single-workload case still gets the plain `image.repository`/
+// `replicaCount` knobs.
+func extractValues(docs []manifestDoc) *chartValues {
+	values := &chartValues{}
+	for _, doc := range docs {
+		if doc.kind != "Deployment" && doc.kind != "StatefulSet" {
+			continue
+		}
+		var d appsv1.Deployment
+		if err := yaml.Unmarshal(doc.raw, &d); err != nil {
+			continue
+		}
+
+		workload := chartWorkloadValues{}
+		if d.Spec.Replicas != nil {
+			workload.ReplicaCount = d.Spec.Replicas
+		}
+		if len(d.Spec.Template.Spec.Containers) > 0 {
+			image := d.Spec.Template.Spec.Containers[0].Image
+			if m := imageRegexp.FindStringSubmatch(image); m != nil {
+				workload.Image.Repository = m[1]
+				workload.Image.Tag = m[2]
+			} else if image != "" {
+				workload.Image.Repository = image
+			}
+		}
+
+		if values.Workloads == nil {
+			values.Workloads = map[string]chartWorkloadValues{}
+		}
+		values.Workloads[doc.name] = workload
+
+		if values.primaryWorkload == "" {
+			values.primaryWorkload = doc.name
+			values.Image = workload.Image
+			values.ReplicaCount = workload.ReplicaCount
+		}
+	}
+
+	for _, doc := range docs {
+		var literals map[string]string
+		switch doc.kind {
+		case "ConfigMap":
+			var cm corev1.ConfigMap
+			if err := yaml.Unmarshal(doc.raw, &cm); err != nil {
+				continue
+			}
+			literals = cm.Data
+		case "Secret":
+			var secret corev1.Secret
+			if err := yaml.Unmarshal(doc.raw, &secret); err != nil {
+				continue
+			}
+			literals = map[string]string{}
+			for k, v := range secret.StringData {
+				literals[k] = v
+			}
+			for k, v := range secret.Data {
+				literals[k] = string(v)
+			}
+		default:
+			continue
+		}
+		if len(literals) == 0 {
+			continue
+		}
+		if values.Config == nil {
+			values.Config = map[string]map[string]string{}
+		}
+		values.Config[doc.name] = literals
+	}
+
+	return values
+}
+
+// templatizeGroup marshals a group of documents back to YAML and rewrites
+// the concrete image/tag/replicas/ConfigMap/Secret literals extracted into
+// values to their `{{ .Values.* }}` equivalents (Secret data is re-templated
+// with `| b64enc` since its literal form on disk is already base64-encoded).
+// Every doc is rewritten only against the values extracted from that same
+// doc (by name), never against another doc's values, so e.g. a manager
+// Deployment and a webhook Deployment that happen to share the same
+// `replicas:`/`image:` literal don't get cross-wired to one `.Values` entry.
+// This is a textual substitution rather than a structural one, because the
+// replacement values are Helm template expressions, not valid YAML scalars,
+// and must survive untouched through to the rendered template.
+func templatizeGroup(docs []manifestDoc, values *chartValues) ([]byte, error) {
+	var out []byte
+	for _, doc := range docs {
+		content := doc.raw
+
+		if workload, ok := values.Workloads[doc.name]; ok && (doc.kind == "Deployment" || doc.kind == "StatefulSet") {
+			imageRef, tagRef, replicaRef := "{{ .Values.image.repository }}", "{{ .Values.image.tag }}", "{{ .Values.replicaCount }}"
+			if doc.name != values.primaryWorkload {
+				workloadKey := fmt.Sprintf("(index .Values.workloads %q)", doc.name)
+				imageRef = fmt.Sprintf("{{ %s.image.repository }}", workloadKey)
+				tagRef = fmt.Sprintf("{{ %s.image.tag }}", workloadKey)
+				replicaRef = fmt.Sprintf("{{ %s.replicaCount }}", workloadKey)
+			}
+
+			if workload.Image.Repository != "" {
+				image := workload.Image.Repository
+				if workload.Image.Tag != "" {
+					image += ":" + workload.Image.Tag
+				}
+				replacement := imageRef
+				if workload.Image.Tag != "" {
+					replacement += ":" + tagRef
+				}
+				content = []byte(strings.ReplaceAll(string(content), "image: "+image, "image: "+replacement))
+			}
+			if workload.ReplicaCount != nil {
+				literal := "replicas: " + strconv.Itoa(int(*workload.ReplicaCount))
+				content = []byte(strings.ReplaceAll(string(content), literal, "replicas: "+replicaRef))
+			}
+		}
+		if cfg, ok := values.Config[doc.name]; ok && (doc.kind == "ConfigMap" || doc.kind == "Secret") {
+			for key, val := range cfg {
+				literal := key + ": " + val
+				replacement := key + fmt.Sprintf(": {{ index .Values.config %q %q }}", doc.name, key)
+				if doc.kind == "Secret" {
+					literal = key + ": " + base64.StdEncoding.EncodeToString([]byte(val))
+					replacement = key + fmt.Sprintf(": {{ index .Values.config %q %q | b64enc }}", doc.name, key)
+				}
+				content = []byte(strings.ReplaceAll(string(content), literal, replacement))
+			}
+		}
+
+		if len(out) > 0 {
+			out = append(out, []byte("---\n")...)
+		}
+		out = append(out, content...)
+		out = append(out, '\n')
+	}
+	return out, nil
+}
+
+// helpersTplFor renders the standard name/fullname/labels helpers found in
+// any `helm create`-scaffolded chart, scoped to chartName.
+func helpersTplFor(chartName string) string {
+	return fmt.Sprintf(`{{- define "%[1]s.name" -}}
+%[1]s
+{{- end -}}
+
+{{- define "%[1]s.fullname" -}}
+{{- .Release.Name }}-%[1]s
+{{- end -}}
+
+{{- define "%[1]s.labels" -}}
+app.kubernetes.io/name: {{ include "%[1]s.name" . }}
+app.kubernetes.io/instance: {{ .Release.Name }}
+app.kubernetes.io/managed-by: {{ .Release.Service }}
+{{- end -}}
+`, chartName)
+}
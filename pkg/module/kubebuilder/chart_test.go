@@ -0,0 +1,200 @@
+package kubebuilder
+
+import (
+	"strings"
+	"testing"
+)
+
+const testManifests = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: controller-manager
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+        - name: manager
+          image: example.com/controller:v1.2.3
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: controller-manager
+`
+
+func TestSplitManifests(t *testing.T) {
+	docs, err := splitManifests([]byte(testManifests))
+	if err != nil {
+		t.Fatalf("splitManifests() returned error: %v", err)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("splitManifests() returned %d docs, want 2", len(docs))
+	}
+	if docs[0].kind != "Deployment" || docs[1].kind != "Service" {
+		t.Fatalf("splitManifests() kinds = [%s, %s], want [Deployment, Service]", docs[0].kind, docs[1].kind)
+	}
+}
+
+func TestExtractValues(t *testing.T) {
+	docs, err := splitManifests([]byte(testManifests))
+	if err != nil {
+		t.Fatalf("splitManifests() returned error: %v", err)
+	}
+
+	values := extractValues(docs)
+	if values.Image.Repository != "example.com/controller" || values.Image.Tag != "v1.2.3" {
+		t.Fatalf("extractValues() image = %+v, want example.com/controller:v1.2.3", values.Image)
+	}
+	if values.ReplicaCount == nil || *values.ReplicaCount != 2 {
+		t.Fatalf("extractValues() replicaCount = %v, want 2", values.ReplicaCount)
+	}
+}
+
+func TestTemplatizeGroupRewritesImageAndReplicas(t *testing.T) {
+	docs, err := splitManifests([]byte(testManifests))
+	if err != nil {
+		t.Fatalf("splitManifests() returned error: %v", err)
+	}
+	values := extractValues(docs)
+
+	content, err := templatizeGroup(docs[:1], values)
+	if err != nil {
+		t.Fatalf("templatizeGroup() returned error: %v", err)
+	}
+
+	if !strings.Contains(string(content), "image: {{ .Values.image.repository }}:{{ .Values.image.tag }}") {
+		t.Fatalf("templatizeGroup() did not rewrite the image field, got:\n%s", content)
+	}
+	if !strings.Contains(string(content), "replicas: {{ .Values.replicaCount }}") {
+		t.Fatalf("templatizeGroup() did not rewrite the replicas field, got:\n%s", content)
+	}
+}
+
+const testConfigManifests = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: controller-config
+data:
+  logLevel: info
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: controller-secret
+data:
+  apiKey: c2VjcmV0
+`
+
+func TestExtractValuesCollectsConfigMapAndSecretLiterals(t *testing.T) {
+	docs, err := splitManifests([]byte(testConfigManifests))
+	if err != nil {
+		t.Fatalf("splitManifests() returned error: %v", err)
+	}
+
+	values := extractValues(docs)
+	if values.Config["controller-config"]["logLevel"] != "info" {
+		t.Fatalf("extractValues() config = %+v, want controller-config.logLevel = info", values.Config)
+	}
+	if values.Config["controller-secret"]["apiKey"] != "secret" {
+		t.Fatalf("extractValues() config = %+v, want controller-secret.apiKey = secret", values.Config)
+	}
+}
+
+func TestTemplatizeGroupRewritesConfigMapAndSecretLiterals(t *testing.T) {
+	docs, err := splitManifests([]byte(testConfigManifests))
+	if err != nil {
+		t.Fatalf("splitManifests() returned error: %v", err)
+	}
+	values := extractValues(docs)
+
+	content, err := templatizeGroup(docs, values)
+	if err != nil {
+		t.Fatalf("templatizeGroup() returned error: %v", err)
+	}
+
+	if !strings.Contains(string(content), `logLevel: {{ index .Values.config "controller-config" "logLevel" }}`) {
+		t.Fatalf("templatizeGroup() did not rewrite the ConfigMap literal, got:\n%s", content)
+	}
+	if !strings.Contains(string(content), `apiKey: {{ index .Values.config "controller-secret" "apiKey" | b64enc }}`) {
+		t.Fatalf("templatizeGroup() did not rewrite the Secret literal, got:\n%s", content)
+	}
+}
+
+const testMultiDeploymentManifests = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: controller-manager
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+        - name: manager
+          image: example.com/controller:v1.2.3
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: webhook
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+        - name: webhook
+          image: example.com/controller:v1.2.3
+`
+
+func TestExtractValuesKeysEveryWorkloadByItsOwnName(t *testing.T) {
+	docs, err := splitManifests([]byte(testMultiDeploymentManifests))
+	if err != nil {
+		t.Fatalf("splitManifests() returned error: %v", err)
+	}
+
+	values := extractValues(docs)
+	if len(values.Workloads) != 2 {
+		t.Fatalf("extractValues() found %d workloads, want 2", len(values.Workloads))
+	}
+	for _, name := range []string{"controller-manager", "webhook"} {
+		workload, ok := values.Workloads[name]
+		if !ok {
+			t.Fatalf("extractValues() workloads = %+v, want an entry for %q", values.Workloads, name)
+		}
+		if workload.Image.Repository != "example.com/controller" || workload.Image.Tag != "v1.2.3" {
+			t.Fatalf("extractValues() workloads[%q].Image = %+v, want example.com/controller:v1.2.3", name, workload.Image)
+		}
+		if workload.ReplicaCount == nil || *workload.ReplicaCount != 2 {
+			t.Fatalf("extractValues() workloads[%q].ReplicaCount = %v, want 2", name, workload.ReplicaCount)
+		}
+	}
+}
+
+func TestTemplatizeGroupDoesNotCrossWireSecondDeployment(t *testing.T) {
+	docs, err := splitManifests([]byte(testMultiDeploymentManifests))
+	if err != nil {
+		t.Fatalf("splitManifests() returned error: %v", err)
+	}
+	values := extractValues(docs)
+
+	content, err := templatizeGroup(docs, values)
+	if err != nil {
+		t.Fatalf("templatizeGroup() returned error: %v", err)
+	}
+
+	if !strings.Contains(string(content), "image: {{ .Values.image.repository }}:{{ .Values.image.tag }}") {
+		t.Fatalf("templatizeGroup() did not rewrite the primary workload's image field, got:\n%s", content)
+	}
+	if !strings.Contains(string(content), "replicas: {{ .Values.replicaCount }}") {
+		t.Fatalf("templatizeGroup() did not rewrite the primary workload's replicas field, got:\n%s", content)
+	}
+
+	webhookImage := `image: {{ (index .Values.workloads "webhook").image.repository }}:{{ (index .Values.workloads "webhook").image.tag }}`
+	webhookReplicas := `replicas: {{ (index .Values.workloads "webhook").replicaCount }}`
+	if !strings.Contains(string(content), webhookImage) {
+		t.Fatalf("templatizeGroup() did not scope the second deployment's image field to its own workload, got:\n%s", content)
+	}
+	if !strings.Contains(string(content), webhookReplicas) {
+		t.Fatalf("templatizeGroup() did not scope the second deployment's replicas field to its own workload, got:\n%s", content)
+	}
+}
@@ -2,7 +2,6 @@ package kubebuilder
 
 import (
 	"fmt"
-	"io/fs"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -59,8 +58,35 @@ func (p *Project) FullName() string {
 	return p.Name
 }
 
-// Build builds the kubebuilder project default kustomization following the given definition.
-func (p *Project) Build(name, version string) (string, error) {
+// ChartPusher pushes an already-built Helm chart directory as an OCI
+// artifact, so module authors get a consumable Helm chart and an
+// OCI-hosted copy in one step. *module.Remote implements this.
+type ChartPusher interface {
+	PushChart(chartPath, name, version string) error
+}
+
+// BuildOption configures optional post-processing for Project.Build.
+type BuildOption func(*buildOptions)
+
+type buildOptions struct {
+	pusher ChartPusher
+}
+
+// WithOCIPush additionally pushes the chart Build produces through pusher.
+func WithOCIPush(pusher ChartPusher) BuildOption {
+	return func(o *buildOptions) { o.pusher = pusher }
+}
+
+// Build builds the kubebuilder project default kustomization following the
+// given definition and emits it as an idiomatic Helm chart: per-kind
+// template files, a generated values.yaml, a standard _helpers.tpl, and
+// CRDs left untouched under crds/ (Helm does not template those).
+func (p *Project) Build(name, version string, opts ...BuildOption) (string, error) {
+	options := &buildOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
 	// check layout
 	if !(slices.Contains(p.Layout, V3) || slices.Contains(p.Layout, V4alpha)) {
 		return "", fmt.Errorf("project layout %v is not supported", p.Layout)
@@ -91,23 +117,8 @@ func (p *Project) Build(name, version string) (string, error) {
 		return "", err
 	}
 
-	if err := os.WriteFile(filepath.Join(outPath, "rendered.yaml"), yml, os.ModePerm); err != nil {
-		return "", fmt.Errorf("could not write rendered kustomization as yml to %s: %w", outPath, err)
-	}
-
-	// move CRDs to their folder
-	mvFn := func(path string, d fs.DirEntry, err error) error {
-		fileName := filepath.Base(path)
-		if strings.Contains(fileName, crdFileIdentifier) {
-			if err := os.Rename(path, filepath.Join(crdsPath, fileName)); err != nil {
-				return fmt.Errorf("could not move CRD file from %q to %q: %w", path, crdsPath, err)
-			}
-		}
-		return nil
-	}
-
-	if err := filepath.WalkDir(outPath, mvFn); err != nil {
-		return "", err
+	if err := writeHelmChart(yml, chartName, outPath, crdsPath); err != nil {
+		return "", fmt.Errorf("could not emit helm chart to %s: %w", chartsPath, err)
 	}
 
 	// generate Chart.yaml file
@@ -115,6 +126,12 @@ func (p *Project) Build(name, version string) (string, error) {
 		return "", fmt.Errorf("could not generate Chart.yaml file: %w", err)
 	}
 
+	if options.pusher != nil {
+		if err := options.pusher.PushChart(chartsPath, chartName, version); err != nil {
+			return "", fmt.Errorf("could not push chart %s:%s as OCI artifact: %w", chartName, version, err)
+		}
+	}
+
 	return chartsPath, nil
 }
 
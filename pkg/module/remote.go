@@ -1,6 +1,7 @@
 package module
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,7 +10,6 @@ import (
 
 	"github.com/open-component-model/ocm/pkg/common"
 	"github.com/open-component-model/ocm/pkg/contexts/credentials"
-	"github.com/open-component-model/ocm/pkg/contexts/credentials/repositories/dockerconfig"
 	oci "github.com/open-component-model/ocm/pkg/contexts/oci/repositories/ocireg"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/attrs/compatattr"
@@ -22,6 +22,8 @@ import (
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler"
 	"github.com/open-component-model/ocm/pkg/contexts/ocm/transfer/transferhandler/standard"
 	"github.com/open-component-model/ocm/pkg/runtime"
+
+	"github.com/kyma-project/cli/pkg/module/bundle"
 )
 
 type NameMapping ocireg.ComponentNameMapping
@@ -38,6 +40,13 @@ type Remote struct {
 	Credentials string
 	Token       string
 	Insecure    bool
+
+	// CredentialProviders is consulted, in order, before the default
+	// docker-config/direct lookup. The first provider to return
+	// non-nil credentials for the registry wins, so e.g. a file-based
+	// provider covering several registries can be layered in front of
+	// (or behind) the docker config.
+	CredentialProviders []CredentialProvider
 }
 
 func (r *Remote) GetRepository(ctx cpi.Context) (cpi.Repository, error) {
@@ -72,29 +81,17 @@ func (r *Remote) getCredentials(ctx cpi.Context) credentials.Credentials {
 	if r.Insecure {
 		return credentials.NewCredentials(nil)
 	}
-	var creds credentials.Credentials
-	if home, err := os.UserHomeDir(); err == nil {
-		path := filepath.Join(home, ".docker", "config.json")
-		if repo, err := dockerconfig.NewRepository(ctx.CredentialsContext(), path, true); err == nil {
-			// this uses the first part of the url to resolve the correct host, e.g.
-			// ghcr.io/jakobmoellersap/testmodule => ghcr.io
-			hostNameInDockerConfigJSON := strings.Split(NoSchemeURL(r.Registry), "/")[0]
-			if creds, err = repo.LookupCredentials(hostNameInDockerConfigJSON); err != nil {
-				// this forces creds to be nil in case the host was not found in the native docker store
-				creds = nil
-			}
-		}
-	}
-	// if no creds are set, try to use username and password that are provided.
-	if creds == nil {
-		u, p := r.userPass()
-		if p == "" {
-			p = r.Token
-		}
-		creds = credentials.DirectCredentials{
-			"username": u,
-			"password": p,
-		}
+
+	u, p := r.userPass()
+	direct := DirectCredentialProvider{Username: u, Password: p, Token: r.Token}
+	chain := append(ChainCredentialProvider{}, r.CredentialProviders...)
+	chain = append(chain, DockerConfigCredentialProvider{}, direct)
+
+	// lookup errors are already handled per-provider by ChainCredentialProvider;
+	// this only has to cover chain.Lookup finding no match at all.
+	creds, err := chain.Lookup(ctx, r.Registry)
+	if err != nil || creds == nil {
+		creds, _ = direct.Lookup(ctx, r.Registry)
 	}
 	return creds
 }
@@ -138,6 +135,54 @@ func (r *Remote) Push(archive *comparch.ComponentArchive, overwrite bool) (ocm.C
 	)
 }
 
+// Bundle packages the archive described in the config, together with every
+// image and Helm chart it references, into an OCI-layout compatible store
+// at storePath. The resulting store is self-contained and can be copied to
+// an air-gapped environment and replayed with Unbundle.
+func (r *Remote) Bundle(archive *comparch.ComponentArchive, storePath string) error {
+	store, err := bundle.NewFileStore(storePath)
+	if err != nil {
+		return fmt.Errorf("could not open bundle store at %q: %w", storePath, err)
+	}
+
+	repo, err := r.GetRepository(archive.GetContext())
+	if err != nil {
+		return err
+	}
+
+	manifest, err := bundle.Bundle(archive, store, repo)
+	if err != nil {
+		return fmt.Errorf("could not bundle %s:%s into %q: %w", archive.ComponentVersionAccess.GetName(), archive.ComponentVersionAccess.GetVersion(), storePath, err)
+	}
+
+	if err := writeManifest(storePath, manifest); err != nil {
+		return fmt.Errorf("could not write bundle manifest to %q: %w", storePath, err)
+	}
+
+	return nil
+}
+
+// Unbundle replays a store produced by Bundle into this Remote, without
+// requiring network access to the component versions' original sources.
+func (r *Remote) Unbundle(ctx cpi.Context, storePath string, overwrite bool) (ocm.ComponentVersionAccess, error) {
+	store, err := bundle.NewFileStore(storePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open bundle store at %q: %w", storePath, err)
+	}
+
+	manifest, err := readManifest(storePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read bundle manifest from %q: %w", storePath, err)
+	}
+
+	repo, err := r.GetRepository(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return bundle.Unbundle(store, manifest, repo, ctx, overwrite)
+}
+
 type customTransferHandler struct {
 	transferhandler.TransferHandler
 }
@@ -145,3 +190,25 @@ type customTransferHandler struct {
 func (h *customTransferHandler) TransferVersion(repo ocm.Repository, src ocm.ComponentVersionAccess, meta *compdesc.ComponentReference) (ocm.ComponentVersionAccess, transferhandler.TransferHandler, error) {
 	return h.TransferHandler.TransferVersion(repo, src, meta)
 }
+
+const bundleManifestFileName = "manifest.json"
+
+func writeManifest(storePath string, manifest bundle.Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not marshal bundle manifest: %w", err)
+	}
+	return os.WriteFile(filepath.Join(storePath, bundleManifestFileName), data, os.ModePerm)
+}
+
+func readManifest(storePath string) (bundle.Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(storePath, bundleManifestFileName))
+	if err != nil {
+		return bundle.Manifest{}, err
+	}
+	var manifest bundle.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return bundle.Manifest{}, fmt.Errorf("could not unmarshal bundle manifest: %w", err)
+	}
+	return manifest, nil
+}
@@ -0,0 +1,116 @@
+package module
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/cpi"
+	"github.com/open-component-model/ocm/pkg/signing"
+	"github.com/spf13/pflag"
+)
+
+// VerifyOptions configures Verify. Keys maps a signature name to the PEM
+// encoded public key that must have produced it. RequireSignature makes
+// verification fail closed when the component version carries no signature
+// at all, rather than treating an unsigned version as trivially verified.
+type VerifyOptions struct {
+	Keys             map[string][]byte
+	RequireSignature bool
+}
+
+// Pull looks up a pushed component version by name and version, without
+// transferring it anywhere - the read-only counterpart to Push.
+func (r *Remote) Pull(ctx cpi.Context, name, version string) (ocm.ComponentVersionAccess, error) {
+	repo, err := r.GetRepository(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cv, err := repo.LookupComponentVersion(name, version)
+	if err != nil {
+		return nil, fmt.Errorf("could not pull %s:%s: %w", name, version, err)
+	}
+
+	return cv, nil
+}
+
+// Verify validates cv's signature(s) against opts, failing closed if
+// RequireSignature is set and no signature is present, or if a present
+// signature does not validate against the given keys.
+func Verify(cv ocm.ComponentVersionAccess, opts VerifyOptions) error {
+	descriptor := cv.GetDescriptor()
+	if len(descriptor.Signatures) == 0 {
+		if opts.RequireSignature {
+			return fmt.Errorf("%s:%s has no signatures but a signature is required", cv.GetName(), cv.GetVersion())
+		}
+		return nil
+	}
+
+	signOpts := []signing.Option{
+		signing.Resolver(cv.Repository()),
+		signing.VerifyDigests(),
+	}
+	for name, key := range opts.Keys {
+		signOpts = append(signOpts, signing.PublicKey(name, key))
+	}
+
+	options := signing.NewOptions(signOpts...)
+	if err := options.Complete(nil); err != nil {
+		return fmt.Errorf("could not prepare signature verification for %s:%s: %w", cv.GetName(), cv.GetVersion(), err)
+	}
+
+	if _, err := signing.Apply(nil, nil, cv, options); err != nil {
+		return fmt.Errorf("signature verification failed for %s:%s: %w", cv.GetName(), cv.GetVersion(), err)
+	}
+
+	return nil
+}
+
+// VerifyFlags binds the --verify-key (repeatable) and --require-signature
+// flags that build a VerifyOptions, so a future command that pulls and
+// verifies a component version can register o.AddFlags(cmd.Flags()) and
+// pass o.Options() into Verify.
+//
+// Known gaps: no cmd/ package in this tree owns such a command yet, so
+// AddFlags is not actually called from anywhere; and there is no
+// --verify-identity equivalent, because the original keyless/cosign-style
+// identity verification this was meant to back
+// (signing.PublicKey(opts.Identity, nil)) never performed real Fulcio/Rekor
+// verification and was removed rather than exposed as a flag - keyless
+// verification is unimplemented, not just unwired.
+type VerifyFlags struct {
+	Keys             []string
+	RequireSignature bool
+}
+
+// AddFlags registers --verify-key (repeatable, "name=path") and
+// --require-signature on flags.
+func (o *VerifyFlags) AddFlags(flags *pflag.FlagSet) {
+	flags.StringArrayVar(&o.Keys, "verify-key", nil, "a \"name=path\" pair pointing at a PEM encoded public key that must have produced the signature called name; can be repeated")
+	flags.BoolVar(&o.RequireSignature, "require-signature", false, "fail verification if the component version carries no signature at all")
+}
+
+// Options builds the VerifyOptions requested by the flags, reading every
+// --verify-key file from disk.
+func (o *VerifyFlags) Options() (VerifyOptions, error) {
+	opts := VerifyOptions{RequireSignature: o.RequireSignature}
+	if len(o.Keys) == 0 {
+		return opts, nil
+	}
+
+	opts.Keys = map[string][]byte{}
+	for _, entry := range o.Keys {
+		name, path, found := strings.Cut(entry, "=")
+		if !found {
+			return VerifyOptions{}, fmt.Errorf("invalid --verify-key %q: want \"name=path\"", entry)
+		}
+		key, err := os.ReadFile(path)
+		if err != nil {
+			return VerifyOptions{}, fmt.Errorf("could not read --verify-key %q: %w", entry, err)
+		}
+		opts.Keys[name] = key
+	}
+	return opts, nil
+}
@@ -0,0 +1,117 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/open-component-model/ocm/pkg/common/accessobj"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm"
+	"github.com/open-component-model/ocm/pkg/contexts/ocm/repositories/comparch"
+	"github.com/open-component-model/ocm/pkg/signing/handlers/rsa"
+	"github.com/spf13/pflag"
+)
+
+const testSignatureName = "kyma-cli-test"
+
+func newSignedTestArchive(t *testing.T) (*comparch.ComponentArchive, []byte) {
+	t.Helper()
+
+	ctx := ocm.New()
+	archive, err := comparch.New(ctx, accessobj.ACC_CREATE, t.TempDir(), nil, comparch.NewComponentDescriptor("test.kyma-project.io/verify", "v0.0.1"))
+	if err != nil {
+		t.Fatalf("could not create test archive: %v", err)
+	}
+
+	privateKey, publicKey, err := rsa.CreateKeyPair()
+	if err != nil {
+		t.Fatalf("could not generate ephemeral signing key: %v", err)
+	}
+
+	if err := rsa.Handler{}.Sign(archive.ComponentVersionAccess, testSignatureName, privateKey); err != nil {
+		t.Fatalf("could not sign test archive: %v", err)
+	}
+
+	return archive, publicKey
+}
+
+func TestVerifyFailsClosedWithoutSignature(t *testing.T) {
+	ctx := ocm.New()
+	archive, err := comparch.New(ctx, accessobj.ACC_CREATE, t.TempDir(), nil, comparch.NewComponentDescriptor("test.kyma-project.io/verify", "v0.0.1"))
+	if err != nil {
+		t.Fatalf("could not create test archive: %v", err)
+	}
+
+	err = Verify(archive.ComponentVersionAccess, VerifyOptions{RequireSignature: true})
+	if err == nil {
+		t.Fatalf("Verify() did not fail for an unsigned component version with RequireSignature set")
+	}
+}
+
+func TestVerifyAcceptsCorrectKey(t *testing.T) {
+	archive, publicKey := newSignedTestArchive(t)
+
+	err := Verify(archive.ComponentVersionAccess, VerifyOptions{
+		Keys: map[string][]byte{testSignatureName: publicKey},
+	})
+	if err != nil {
+		t.Fatalf("Verify() returned error for a validly signed component version: %v", err)
+	}
+}
+
+func TestVerifyRejectsWrongKey(t *testing.T) {
+	archive, _ := newSignedTestArchive(t)
+	_, wrongKey, err := rsa.CreateKeyPair()
+	if err != nil {
+		t.Fatalf("could not generate ephemeral key: %v", err)
+	}
+
+	err = Verify(archive.ComponentVersionAccess, VerifyOptions{
+		Keys: map[string][]byte{testSignatureName: wrongKey},
+	})
+	if err == nil {
+		t.Fatalf("Verify() did not fail for a signature checked against the wrong public key")
+	}
+}
+
+func TestVerifyFlagsOptionsReadsKeyFiles(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "signer.pem")
+	if err := os.WriteFile(keyPath, []byte("fake-pem-contents"), 0o600); err != nil {
+		t.Fatalf("could not write test key file: %v", err)
+	}
+
+	flags := VerifyFlags{Keys: []string{testSignatureName + "=" + keyPath}, RequireSignature: true}
+	opts, err := flags.Options()
+	if err != nil {
+		t.Fatalf("Options() returned error: %v", err)
+	}
+	if !opts.RequireSignature {
+		t.Fatalf("Options() RequireSignature = false, want true")
+	}
+	if string(opts.Keys[testSignatureName]) != "fake-pem-contents" {
+		t.Fatalf("Options() Keys[%q] = %q, want fake-pem-contents", testSignatureName, opts.Keys[testSignatureName])
+	}
+}
+
+func TestVerifyFlagsOptionsRejectsMalformedKeyFlag(t *testing.T) {
+	flags := VerifyFlags{Keys: []string{"no-equals-sign"}}
+	if _, err := flags.Options(); err == nil {
+		t.Fatalf("Options() did not fail for a --verify-key flag missing \"name=path\"")
+	}
+}
+
+func TestVerifyFlagsAddFlagsRegistersFlags(t *testing.T) {
+	var flags VerifyFlags
+	set := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.AddFlags(set)
+
+	if err := set.Parse([]string{"--verify-key", "a=b", "--verify-key", "c=d", "--require-signature"}); err != nil {
+		t.Fatalf("Parse() returned error: %v", err)
+	}
+	if len(flags.Keys) != 2 || flags.Keys[0] != "a=b" || flags.Keys[1] != "c=d" {
+		t.Fatalf("--verify-key = %v, want [a=b c=d]", flags.Keys)
+	}
+	if !flags.RequireSignature {
+		t.Fatalf("--require-signature = false, want true")
+	}
+}